@@ -0,0 +1,223 @@
+package apiclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+//Aggregator wraps a set of APIClient implementations, one per exchange,
+//and exposes cross-exchange operations on top of their single-exchange
+//primitives (GetOrderBook, GetTicker, PlaceOrder, ...).
+type Aggregator struct {
+	// Venues maps an exchange name (example "binance", "kraken") to the
+	// APIClient used to reach it. The name is only used to label results;
+	// Aggregator does not otherwise care what it is.
+	Venues map[string]APIClient
+}
+
+//NewAggregator builds an Aggregator over venues.
+func NewAggregator(venues map[string]APIClient) *Aggregator {
+	return &Aggregator{Venues: venues}
+}
+
+//MergedLevel is a single order book level annotated with the venue it came from.
+type MergedLevel struct {
+	Order
+	Venue string
+}
+
+//MergedOrderBook is an OrderBook merged across every venue in an
+//Aggregator, with each level annotated by the venue it was sourced from.
+type MergedOrderBook struct {
+	Asks []MergedLevel // sorted by Price ascending
+	Bids []MergedLevel // sorted by Price descending
+}
+
+//MergedOrderBook merges the order book for one pair across every venue in
+//symbols, which maps a venue name to that venue's native symbol for the
+//pair; venues of the Aggregator missing from symbols are not queried.
+//symbol resolution is left to the caller: venues map keys to an APIClient,
+//and each APIClient's GetOrderBook is called with the symbol parameter the
+//caller passes in, so callers typically resolve per-venue native symbols
+//via SymbolRegistry.ResolveSymbol before calling this.
+func (a *Aggregator) MergedOrderBook(symbols map[string]string, depth int) (*MergedOrderBook, error) {
+	merged := &MergedOrderBook{}
+
+	for venue, symbol := range symbols {
+		client, ok := a.Venues[venue]
+		if !ok {
+			return nil, fmt.Errorf("apiclient: unknown venue %s", venue)
+		}
+
+		book, err := client.GetOrderBook(symbol, depth)
+		if err != nil {
+			return nil, fmt.Errorf("apiclient: %s GetOrderBook: %w", venue, err)
+		}
+
+		for _, ask := range book.Asks {
+			merged.Asks = append(merged.Asks, MergedLevel{Order: ask, Venue: venue})
+		}
+		for _, bid := range book.Bids {
+			merged.Bids = append(merged.Bids, MergedLevel{Order: bid, Venue: venue})
+		}
+	}
+
+	sort.Slice(merged.Asks, func(i, j int) bool { return merged.Asks[i].Price < merged.Asks[j].Price })
+	sort.Slice(merged.Bids, func(i, j int) bool { return merged.Bids[i].Price > merged.Bids[j].Price })
+
+	return merged, nil
+}
+
+//BestBidAsk returns the best executable bid and ask across every venue in
+//symbols, and which venue each one came from.
+func (a *Aggregator) BestBidAsk(symbols map[string]string) (bestBid MergedLevel, bestAsk MergedLevel, err error) {
+	book, err := a.MergedOrderBook(symbols, 1)
+	if err != nil {
+		return MergedLevel{}, MergedLevel{}, err
+	}
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return MergedLevel{}, MergedLevel{}, fmt.Errorf("apiclient: empty merged order book")
+	}
+	return book.Bids[0], book.Asks[0], nil
+}
+
+//ArbOpportunity is a single spatial arbitrage opportunity found by FindArbitrage.
+type ArbOpportunity struct {
+	BuyVenue  string
+	SellVenue string
+
+	BuyPrice  float64
+	SellPrice float64
+
+	// SpreadBps is the fee-adjusted spread in basis points: sell proceeds
+	// minus buy cost, over buy cost, where each price is adjusted by its
+	// venue's taker fee from FindArbitrage's fees.
+	SpreadBps float64
+}
+
+//FindArbitrage looks for spatial arbitrage across every pair of venues in
+//symbols: for each pair of distinct venues, buy at one venue's best ask and
+//sell at the other's best bid, net of each venue's taker fee. fees maps venue name to its
+//taker fee as a fraction (0.001 == 0.1%); a venue missing from fees is
+//treated as fee-free. Only opportunities at or above minSpreadBps are returned.
+func (a *Aggregator) FindArbitrage(symbols map[string]string, fees map[string]float64, minSpreadBps float64) ([]ArbOpportunity, error) {
+	book, err := a.MergedOrderBook(symbols, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var opportunities []ArbOpportunity
+	for _, ask := range book.Asks {
+		for _, bid := range book.Bids {
+			if ask.Venue == bid.Venue {
+				continue
+			}
+
+			buyCost := ask.Price * (1 + fees[ask.Venue])
+			sellProceeds := bid.Price * (1 - fees[bid.Venue])
+			if sellProceeds <= buyCost {
+				continue
+			}
+
+			spreadBps := (sellProceeds - buyCost) / buyCost * 10000
+			if spreadBps < minSpreadBps {
+				continue
+			}
+
+			opportunities = append(opportunities, ArbOpportunity{
+				BuyVenue:  ask.Venue,
+				SellVenue: bid.Venue,
+				BuyPrice:  ask.Price,
+				SellPrice: bid.Price,
+				SpreadBps: spreadBps,
+			})
+		}
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool { return opportunities[i].SpreadBps > opportunities[j].SpreadBps })
+	return opportunities, nil
+}
+
+//venueFill accumulates how much of a routed order a single venue picked up
+//across one or more order book levels, so RouteOrder can place one order
+//per venue instead of one per level.
+type venueFill struct {
+	amount   float64
+	notional float64 // sum of price*amount, used to derive a volume-weighted price
+}
+
+//RouteOrder splits req across the venues in symbols by walking the merged
+//order book best price first and taking depth up to priceCap from whichever
+//venue offers it, placing one order per venue it routes to at that venue's
+//volume-weighted average price across whichever levels it contributed. A
+//venue's share is the depth it offers at better prices than the others, not
+//a share in proportion to its total depth. It stops consuming depth once
+//req.Amount is filled or every venue's depth up to priceCap is exhausted;
+//any unfilled remainder is left unplaced. depth is how many order book
+//levels per venue to consider, the same convention MergedOrderBook uses.
+func (a *Aggregator) RouteOrder(req OrderRequest, symbols map[string]string, priceCap float64, depth int) ([]MakedOrder, error) {
+	var book *MergedOrderBook
+	var err error
+	if book, err = a.MergedOrderBook(symbols, depth); err != nil {
+		return nil, err
+	}
+
+	levels := book.Asks
+	if req.Side == Sell {
+		levels = book.Bids
+	}
+
+	remaining := req.Amount
+	fills := make(map[string]*venueFill)
+	var venueOrder []string // preserves first-touched order for deterministic placement
+
+	for _, level := range levels {
+		if remaining <= 0 {
+			break
+		}
+		if req.Side == Buy && level.Price > priceCap {
+			continue
+		}
+		if req.Side == Sell && level.Price < priceCap {
+			continue
+		}
+		if _, ok := a.Venues[level.Venue]; !ok {
+			continue
+		}
+
+		amount := level.Quantity
+		if amount > remaining {
+			amount = remaining
+		}
+
+		fill, ok := fills[level.Venue]
+		if !ok {
+			fill = &venueFill{}
+			fills[level.Venue] = fill
+			venueOrder = append(venueOrder, level.Venue)
+		}
+		fill.amount += amount
+		fill.notional += amount * level.Price
+
+		remaining -= amount
+	}
+
+	var placed []MakedOrder
+	for _, venue := range venueOrder {
+		fill := fills[venue]
+
+		venueReq := req
+		venueReq.Symbol = symbols[venue]
+		venueReq.Amount = fill.amount
+		venueReq.Price = fill.notional / fill.amount
+
+		order, err := a.Venues[venue].PlaceOrder(venueReq)
+		if err != nil {
+			return placed, fmt.Errorf("apiclient: %s PlaceOrder: %w", venue, err)
+		}
+
+		placed = append(placed, *order)
+	}
+
+	return placed, nil
+}
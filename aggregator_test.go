@@ -0,0 +1,112 @@
+package apiclient
+
+import "testing"
+
+//fakeClient is a minimal APIClient stub: only the methods Aggregator
+//actually calls (GetOrderBook, PlaceOrder) do anything.
+type fakeClient struct {
+	APIClient
+	book   *OrderBook
+	placed []OrderRequest
+}
+
+//GetOrderBook mimics a real exchange client: depth caps how many levels
+//come back, and an explicit depth of 0 means zero levels, not "unlimited".
+func (f *fakeClient) GetOrderBook(symbol string, depth ...int) (*OrderBook, error) {
+	d := 50
+	if len(depth) > 0 {
+		d = depth[0]
+	}
+
+	book := &OrderBook{}
+	if d < len(f.book.Asks) {
+		book.Asks = f.book.Asks[:d]
+	} else {
+		book.Asks = f.book.Asks
+	}
+	if d < len(f.book.Bids) {
+		book.Bids = f.book.Bids[:d]
+	} else {
+		book.Bids = f.book.Bids
+	}
+	return book, nil
+}
+
+func (f *fakeClient) PlaceOrder(req OrderRequest) (*MakedOrder, error) {
+	f.placed = append(f.placed, req)
+	return &MakedOrder{Side: req.Side, LeftAmount: req.Amount, Rate: req.Price}, nil
+}
+
+func TestRouteOrderFillsAcrossVenues(t *testing.T) {
+	binance := &fakeClient{book: &OrderBook{
+		Asks: []Order{{Price: 100, Quantity: 1}},
+	}}
+	kraken := &fakeClient{book: &OrderBook{
+		Asks: []Order{{Price: 101, Quantity: 5}},
+	}}
+
+	agg := NewAggregator(map[string]APIClient{"binance": binance, "kraken": kraken})
+	symbols := map[string]string{"binance": "USDT_BTC", "kraken": "XXBTZUSD"}
+
+	placed, err := agg.RouteOrder(OrderRequest{Side: Buy, Amount: 3}, symbols, 200, 10)
+	if err != nil {
+		t.Fatalf("RouteOrder: %v", err)
+	}
+
+	var total float64
+	for _, order := range placed {
+		total += order.LeftAmount
+	}
+	if total != 3 {
+		t.Fatalf("expected 3 total amount routed, got %v (placed=%d)", total, len(placed))
+	}
+	if len(binance.placed) != 1 || len(kraken.placed) != 1 {
+		t.Fatalf("expected one order per venue, binance=%d kraken=%d", len(binance.placed), len(kraken.placed))
+	}
+}
+
+func TestRouteOrderCollapsesMultipleLevelsIntoOneOrderPerVenue(t *testing.T) {
+	binance := &fakeClient{book: &OrderBook{
+		Asks: []Order{
+			{Price: 100, Quantity: 1},
+			{Price: 101, Quantity: 1},
+			{Price: 102, Quantity: 1},
+		},
+	}}
+
+	agg := NewAggregator(map[string]APIClient{"binance": binance})
+	symbols := map[string]string{"binance": "USDT_BTC"}
+
+	placed, err := agg.RouteOrder(OrderRequest{Side: Buy, Amount: 3}, symbols, 200, 10)
+	if err != nil {
+		t.Fatalf("RouteOrder: %v", err)
+	}
+
+	if len(binance.placed) != 1 {
+		t.Fatalf("expected a single PlaceOrder call for binance despite 3 levels, got %d", len(binance.placed))
+	}
+	if len(placed) != 1 {
+		t.Fatalf("expected a single MakedOrder back, got %d", len(placed))
+	}
+	if placed[0].LeftAmount != 3 {
+		t.Fatalf("expected the single order to cover the full 3 amount, got %v", placed[0].LeftAmount)
+	}
+
+	wantVWAP := (100.0 + 101.0 + 102.0) / 3
+	if placed[0].Rate != wantVWAP {
+		t.Fatalf("expected volume-weighted price %v, got %v", wantVWAP, placed[0].Rate)
+	}
+}
+
+func TestRouteOrderZeroDepthPlacesNothing(t *testing.T) {
+	binance := &fakeClient{book: &OrderBook{Asks: []Order{{Price: 100, Quantity: 1}}}}
+	agg := NewAggregator(map[string]APIClient{"binance": binance})
+
+	placed, err := agg.RouteOrder(OrderRequest{Side: Buy, Amount: 1}, map[string]string{"binance": "USDT_BTC"}, 200, 0)
+	if err != nil {
+		t.Fatalf("RouteOrder: %v", err)
+	}
+	if len(placed) != 0 {
+		t.Fatalf("expected no orders placed with depth=0, got %d", len(placed))
+	}
+}
@@ -1,7 +1,5 @@
 package apiclient
 
-import "io"
-
 //APIClient interface for all exchange api
 type APIClient interface {
 	// INIT
@@ -88,9 +86,33 @@ type APIClient interface {
 	 *
 	 * returns:
 	 *   Returns a pointer to apiclient.MarketData structure and error.
+	 *
+	 * GetMarketData is kept for backward compat; implementations should
+	 * build it on top of GetTicker.
 	 */
 	GetMarketData(symbol string) (*MarketData, error)
 
+	/* Retrieves a full ticker snapshot for symbol.
+	 *
+	 * arguments:
+	 *   symbol  pair text-id in apiclient format.
+	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
+	 *
+	 * returns:
+	 *   Returns a pointer to apiclient.Ticker structure and error.
+	 */
+	GetTicker(symbol string) (*Ticker, error)
+
+	/* Retrieves a ticker snapshot for every symbol the exchange lists.
+	 * Implementations should prefer the exchange's native multi-ticker
+	 * endpoint over looping GetTicker per symbol.
+	 *
+	 * returns:
+	 *   Returns a map of symbol to a pointer to apiclient.Ticker structure
+	 *   and error.
+	 */
+	GetAllTickers() (map[string]*Ticker, error)
+
 	/* Retrieves all trading pairs from exchange
 	 *
 	 * returns:
@@ -98,7 +120,41 @@ type APIClient interface {
 	 *   symbol  pair text-id in apiclient format.
 	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
 	 */
-	GetTradingPairs(symbol string) (*[]string, error)
+	GetTradingPairs() (*[]string, error)
+
+	/* Retrieves market metadata (limits, tick/step sizes, status) for every
+	 * trading pair the exchange lists.
+	 *
+	 * returns:
+	 *   Returns a pointer to an array of apiclient.Market structure and error.
+	 */
+	GetMarkets() (*[]Market, error)
+
+	/* Resolves a base/quote currency pair to this exchange's native symbol.
+	 * Backed by a SymbolRegistry built from GetMarkets/GetTradingPairs and
+	 * cached for the lifetime of the client.
+	 *
+	 * arguments:
+	 *   base   currency id in string format: <raw-line, uppercase> (BTC)
+	 *   quote  currency id in string format: <raw-line, uppercase> (USDT)
+	 *
+	 * returns:
+	 *   Returns the exchange's native symbol (example "USDT_BTC") and error.
+	 */
+	ResolveSymbol(base string, quote string) (symbol string, err error)
+
+	/* Parses this exchange's native symbol back into base/quote currencies.
+	 * The inverse of ResolveSymbol.
+	 *
+	 * arguments:
+	 *   symbol  pair text-id in apiclient format.
+	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
+	 *
+	 * returns:
+	 *   Returns base and quote currency ids in string format: <raw-line,
+	 *   uppercase> (BTC, USDT) and error.
+	 */
+	ParseSymbol(symbol string) (base string, quote string, err error)
 
 	// PRIVATE API
 
@@ -121,7 +177,20 @@ type APIClient interface {
 	 */
 	GetOrderStatus(id string, symbol string) (*MakedOrder, error)
 
-	/* Put Limit Sell order.
+	/* Places an order of any apiclient.OrderType (Limit, MarketOrder, StopLimit,
+	 * StopMarket or OCO).
+	 *
+	 * arguments:
+	 *   req  apiclient.OrderRequest structure describing the order. Fields
+	 *        that don't apply to req.Type are ignored by the implementation
+	 *        (example: StopPrice on a plain Limit order).
+	 *
+	 * returns:
+	 *   Returns a pointer to apiclient.MakedOrder structure and error.
+	 */
+	PlaceOrder(req OrderRequest) (*MakedOrder, error)
+
+	/* Put Limit Sell order. Thin wrapper over PlaceOrder.
 	 *
 	 * arguments:
 	 *   symbol:   pair text-id in apiclient format
@@ -136,7 +205,7 @@ type APIClient interface {
 	 */
 	Sell(symbol string, amount float64, price float64, check ...bool) (*MakedOrder, error)
 
-	/* Put Limit Buy order.
+	/* Put Limit Buy order. Thin wrapper over PlaceOrder.
 	 *
 	 * arguments:
 	 *   symbol:   pair text-id in apiclient format
@@ -149,7 +218,7 @@ type APIClient interface {
 	 * returns:
 	 *   Returns a pointer to apiclient.MakedOrder structure and error.
 	 */
-	Buy(symbol string, amount float64, price float6, check ...bool4) (*MakedOrder, error)
+	Buy(symbol string, amount float64, price float64, check ...bool) (*MakedOrder, error)
 
 	/* Cancel order.
 	 *
@@ -228,14 +297,14 @@ type APIClient interface {
 	 * returns:
 	 *   Returns a pointer to an array of apiclient.Transfer structure and error.
 	 */
-	GetWithdrawList() (*[]Transfer, err error)
-	
+	GetWithdrawList() (*[]Transfer, error)
+
 	/* Get deposit list.
 	 *
 	 * returns:
 	 *   Returns a pointer to an array of apiclient.Transfer structure and error.
 	 */
-	GetDepositList() (*[]Transfer, err error)
+	GetDepositList() (*[]Transfer, error)
 }
 
 //Status type for enum about order status
@@ -247,6 +316,12 @@ type Side string
 //Color type for volume candle color
 type Color string
 
+//OrderType type for enum about order placement type, used by OrderRequest
+type OrderType string
+
+//TimeInForce type for enum about how long an order stays open, used by OrderRequest
+type TimeInForce string
+
 //constants about Status and Side
 const (
 	Buy  Side = "BUY"
@@ -261,6 +336,20 @@ const (
 	Green Color = "rgba(0, 150, 136, 0.5)" // UP or BUY
 )
 
+//constants about OrderType and TimeInForce
+const (
+	Limit       OrderType = "LIMIT"
+	MarketOrder OrderType = "MARKET"
+	StopLimit   OrderType = "STOP_LIMIT"
+	StopMarket  OrderType = "STOP_MARKET"
+	OCO         OrderType = "OCO"
+
+	GTC      TimeInForce = "GTC" // Good Till Cancel
+	IOC      TimeInForce = "IOC" // Immediate Or Cancel
+	FOK      TimeInForce = "FOK" // Fill Or Kill
+	PostOnly TimeInForce = "POST_ONLY"
+)
+
 //Balance help struct for APIClient
 type Balance struct {
 	Free   float64 `json:"free"`   // Available balance for use in new orders
@@ -300,6 +389,45 @@ type MakedOrder struct {
 
 	// Side Should be one of apiclient.Side constants(Buy, Sell)
 	Side Side `json:"side"`
+
+	// Type Should be one of apiclient.OrderType constants(Limit, MarketOrder, StopLimit, StopMarket, OCO)
+	Type OrderType `json:"type"`
+
+	// TimeInForce Should be one of apiclient.TimeInForce constants(GTC, IOC, FOK, PostOnly)
+	TimeInForce TimeInForce `json:"timeInForce"`
+
+	// ClientOrderID echoes OrderRequest.ClientOrderID, empty if none was given
+	ClientOrderID string `json:"clientOrderId"`
+
+	// StopPrice is the trigger price for StopLimit/StopMarket/OCO orders, 0 for Limit/Market
+	StopPrice float64 `json:"stopPrice"`
+
+	// AvgFillPrice is the amount-weighted average price across all fills so far
+	AvgFillPrice float64 `json:"avgFillPrice"`
+}
+
+//OrderRequest help struct for APIClient.PlaceOrder
+type OrderRequest struct {
+	Symbol string // pair text-id in apiclient format (example "USDT_BTC")
+	Side   Side   // apiclient.Side constant(Buy, Sell)
+	Type   OrderType // apiclient.OrderType constant(Limit, MarketOrder, StopLimit, StopMarket, OCO)
+
+	Amount float64 // token amount, required for all types except market-by-quote
+	Price  float64 // one token price, required for Limit/StopLimit/OCO
+
+	// StopPrice is the trigger price, required for StopLimit/StopMarket/OCO
+	StopPrice float64
+
+	// TimeInForce not required argument (default value GTC)
+	TimeInForce TimeInForce
+
+	// ClientOrderID not required argument, echoed back on MakedOrder.ClientOrderID
+	ClientOrderID string
+
+	// QuoteAmount not required argument, spend/receive this much of the
+	// right-hand currency instead of Amount tokens of the left-hand one.
+	// Only valid on Market orders (market-by-quote).
+	QuoteAmount float64
 }
 
 //KLine help struct for APIClient
@@ -349,6 +477,30 @@ type MarketData struct {
 	DayPriceLow     float64 `json:"dayPriceLow"`
 }
 
+//Ticker help struct for APIClient, a unified snapshot of an exchange's
+//best bid/ask and 24h stats for a symbol.
+type Ticker struct {
+	Bid     float64 `json:"bid"`
+	BidSize float64 `json:"bidSize"`
+	Ask     float64 `json:"ask"`
+	AskSize float64 `json:"askSize"`
+	Last    float64 `json:"last"`
+
+	Open float64 `json:"open"`
+	High float64 `json:"high"` // 24h high
+	Low  float64 `json:"low"`  // 24h low
+
+	VWAP float64 `json:"vwap"` // 24h volume-weighted average price
+
+	Volume      float64 `json:"volume"`      // 24h base volume
+	QuoteVolume float64 `json:"quoteVolume"` // 24h quote volume
+	NumTrades   int64   `json:"numTrades"`   // 24h trade count
+
+	Time int64 `json:"time"` // UNIX time in seconds (10 digits)
+
+	PercentChange24h float64 `json:"percentChange24h"`
+}
+
 type Transfer struct {
 	Time     int64   `json:"time"` // UNIX time in seconds (10 digits)
 	Amount   float64 `json:"amount"`
@@ -0,0 +1,149 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+//APIClientCtx mirrors APIClient with every method taking a context.Context
+//as the first parameter, so callers can cancel, time out, or attach
+//deadlines/values the way net/http and most Go client libraries expect.
+//Implementations typically wrap an APIClient and thread ctx through to the
+//underlying HTTP/websocket calls, the rate limiter and the retry policy.
+type APIClientCtx interface {
+	GetLastPrice(ctx context.Context, symbol string) (lastPrice float64, err error)
+	GetOrderBook(ctx context.Context, symbol string, depth ...int) (*OrderBook, error)
+	GetDecs(ctx context.Context, symbol string) (*Decimals, error)
+	GetKLine(ctx context.Context, symbol string, candlePeriod int, number int) (*KLine, error)
+	GetTradeHistory(ctx context.Context, symbol string, number int) (*[]Trade, error)
+	GetMarketData(ctx context.Context, symbol string) (*MarketData, error)
+	GetTicker(ctx context.Context, symbol string) (*Ticker, error)
+	GetAllTickers(ctx context.Context) (map[string]*Ticker, error)
+	GetTradingPairs(ctx context.Context) (*[]string, error)
+	GetMarkets(ctx context.Context) (*[]Market, error)
+	ResolveSymbol(ctx context.Context, base string, quote string) (symbol string, err error)
+	ParseSymbol(ctx context.Context, symbol string) (base string, quote string, err error)
+
+	GetBalances(ctx context.Context) (*map[string]Balance, error)
+	GetOrderStatus(ctx context.Context, id string, symbol string) (*MakedOrder, error)
+	PlaceOrder(ctx context.Context, req OrderRequest) (*MakedOrder, error)
+	Sell(ctx context.Context, symbol string, amount float64, price float64, check ...bool) (*MakedOrder, error)
+	Buy(ctx context.Context, symbol string, amount float64, price float64, check ...bool) (*MakedOrder, error)
+	CancelOrder(ctx context.Context, symbol string, id string) error
+	CancelAll(ctx context.Context, symbol string) error
+	GetMyOpenOrders(ctx context.Context, symbol string) (*[]MakedOrder, error)
+	GetMyTradeHistory(ctx context.Context, symbol string, period string) (*[]MakedOrder, error)
+	GetMyOrderHistory(ctx context.Context, symbol string, period string) (*[]MakedOrder, error)
+	Withdraw(ctx context.Context, asset string, address string, amount float64, chain string) (id string, err error)
+	GetWithdrawList(ctx context.Context) (*[]Transfer, error)
+	GetDepositList(ctx context.Context) (*[]Transfer, error)
+}
+
+//RateLimiter throttles outgoing requests. Implementations are typically a
+//token bucket keyed by endpoint, since exchanges rate-limit differently per
+//endpoint (Binance: request weight per endpoint, Kraken: a call-counter
+//that decays over time).
+type RateLimiter interface {
+	// Wait blocks until a request against endpoint is allowed to proceed,
+	// or ctx is done.
+	Wait(ctx context.Context, endpoint string) error
+}
+
+//RetryPolicy configures how an APIClientCtx implementation retries
+//transient failures (5xx, 429) before giving up.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between retries.
+	MaxBackoff time.Duration
+	// HonorRetryAfter, when true, uses the Retry-After response header
+	// instead of the computed backoff when the server provides one.
+	HonorRetryAfter bool
+}
+
+//DefaultRetryPolicy is used by APIClientCtx implementations that are
+//constructed without an explicit RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	InitialBackoff:  500 * time.Millisecond,
+	MaxBackoff:      10 * time.Second,
+	HonorRetryAfter: true,
+}
+
+//Middleware wraps a single outgoing request/response round trip, in the
+//same spirit as http.RoundTripper, so cross-cutting concerns (logging,
+//metrics, signing) can be composed without touching every method.
+type Middleware func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error)
+
+//Logger is the minimal logging surface APIClientCtx implementations call
+//into; *log.Logger satisfies it as is, and most structured loggers satisfy
+//it with a thin adapter.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+//Option configures an APIClientCtx implementation at construction time.
+//Exchanges expose a constructor such as:
+//
+//	client, err := binance.NewClient(accountID, apiKey, apiSecret,
+//		apiclient.WithHTTPClient(httpClient),
+//		apiclient.WithRateLimit(limiter),
+//		apiclient.WithRetry(apiclient.DefaultRetryPolicy),
+//	)
+//
+//Init(accountID, apiKey, apiSecret) remains the zero-option constructor
+//for backward compat with the plain APIClient interface.
+type Option func(*Options)
+
+//Options collects the values set by Option functions. Exchange
+//constructors read it after applying every Option; it is not part of the
+//public API surface beyond that.
+type Options struct {
+	HTTPClient *http.Client
+	RateLimit  RateLimiter
+	Retry      RetryPolicy
+	Logger     Logger
+	Middleware []Middleware
+}
+
+//WithHTTPClient overrides the *http.Client used for outgoing requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *Options) { o.HTTPClient = c }
+}
+
+//WithRateLimit installs a RateLimiter. Without one, implementations fall
+//back to whatever conservative default suits the exchange.
+func WithRateLimit(l RateLimiter) Option {
+	return func(o *Options) { o.RateLimit = l }
+}
+
+//WithRetry overrides the RetryPolicy. Without one, DefaultRetryPolicy applies.
+func WithRetry(p RetryPolicy) Option {
+	return func(o *Options) { o.Retry = p }
+}
+
+//WithLogger installs a Logger implementations use to report retries,
+//reconnects and other operational events.
+func WithLogger(l Logger) Option {
+	return func(o *Options) { o.Logger = l }
+}
+
+//WithMiddleware appends a request/response Middleware, applied in the
+//order given.
+func WithMiddleware(m Middleware) Option {
+	return func(o *Options) { o.Middleware = append(o.Middleware, m) }
+}
+
+//NewOptions applies every Option over a copy of DefaultRetryPolicy and
+//returns the resulting Options. Exchange constructors call this so that
+//WithRetry remains optional.
+func NewOptions(opts ...Option) *Options {
+	o := &Options{Retry: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,14 @@
+package apiclient
+
+import "errors"
+
+//ErrOrderBookGap is returned by OrderBookSync.ApplyDelta when an
+//incremental update arrives out of sequence, meaning the local order book
+//snapshot is stale and must be rebuilt from GetOrderBook.
+var ErrOrderBookGap = errors.New("apiclient: order book sequence gap, resync required")
+
+//ErrChecksumMismatch is returned by OrderBookSync.ApplyDelta when the
+//exchange-provided checksum no longer matches OrderBookSync.Checksum,
+//meaning the local order book has drifted and must be rebuilt from
+//GetOrderBook.
+var ErrChecksumMismatch = errors.New("apiclient: order book checksum mismatch, resync required")
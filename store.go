@@ -0,0 +1,234 @@
+package apiclient
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+//HistoryStore persists candles and trades on disk so CachedAPIClient can
+//serve GetKLine/GetTradeHistory locally and only call the exchange to fill
+//gaps. A backend can be a Bolt or SQLite file for random-access reads, or
+//Parquet for bulk analytical access; this package defines only the
+//interface and ships no backend implementation.
+type HistoryStore interface {
+	// LoadCandles returns every stored candle for symbol/period within
+	// [from, to], time-sorted ascending.
+	LoadCandles(symbol string, period int, from time.Time, to time.Time) ([]PriceCandle, error)
+
+	// SaveCandles upserts candles for symbol/period, keyed by PriceCandle.Time.
+	SaveCandles(symbol string, period int, candles []PriceCandle) error
+
+	// LoadTrades returns every stored trade for symbol within [from, to],
+	// time-sorted ascending.
+	LoadTrades(symbol string, from time.Time, to time.Time) ([]Trade, error)
+
+	// SaveTrades appends trades for symbol.
+	SaveTrades(symbol string, trades []Trade) error
+
+	Close() error
+}
+
+//CachedAPIClient decorates an APIClient with a HistoryStore: GetKLine and
+//GetTradeHistory are served from the store when the requested range is
+//already present, and the wrapped APIClient is only called to backfill
+//whatever is missing.
+type CachedAPIClient struct {
+	APIClient
+	Store HistoryStore
+}
+
+//NewCachedAPIClient wraps client with store.
+func NewCachedAPIClient(client APIClient, store HistoryStore) *CachedAPIClient {
+	return &CachedAPIClient{APIClient: client, Store: store}
+}
+
+//GetKLine serves symbol/candlePeriod/number from Store when available. On a
+//partial hit it only asks the wrapped APIClient for the missing candles
+//(GetKLine always returns the most recent N candles, so a cache that is
+//behind real time is only missing its newest tail) and merges that gap into
+//what was already cached instead of refetching and overwriting the lot.
+func (c *CachedAPIClient) GetKLine(symbol string, candlePeriod int, number int) (*KLine, error) {
+	to := time.Now()
+	from := to.Add(-time.Duration(number*candlePeriod) * time.Minute)
+
+	cached, err := c.Store.LoadCandles(symbol, candlePeriod, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("apiclient: LoadCandles: %w", err)
+	}
+	if len(cached) >= number {
+		return &KLine{PriceCandles: cached[len(cached)-number:]}, nil
+	}
+
+	missing := number - len(cached)
+	kline, err := c.APIClient.GetKLine(symbol, candlePeriod, missing)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Store.SaveCandles(symbol, candlePeriod, kline.PriceCandles); err != nil {
+		return nil, fmt.Errorf("apiclient: SaveCandles: %w", err)
+	}
+
+	merged := mergeCandles(cached, kline.PriceCandles)
+	if len(merged) > number {
+		merged = merged[len(merged)-number:]
+	}
+	return &KLine{PriceCandles: merged}, nil
+}
+
+//mergeCandles combines two ascending, PriceCandle.Time-deduplicated runs
+//into one ascending run, keeping fresh's value on a timestamp collision.
+func mergeCandles(cached []PriceCandle, fresh []PriceCandle) []PriceCandle {
+	byTime := make(map[int64]PriceCandle, len(cached)+len(fresh))
+	for _, candle := range cached {
+		byTime[candle.Time] = candle
+	}
+	for _, candle := range fresh {
+		byTime[candle.Time] = candle
+	}
+
+	merged := make([]PriceCandle, 0, len(byTime))
+	for _, candle := range byTime {
+		merged = append(merged, candle)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Time < merged[j].Time })
+	return merged
+}
+
+//GetTradeHistory serves symbol/number from Store when available. On a
+//partial hit it only asks the wrapped APIClient for the missing trades
+//(GetTradeHistory always returns the most recent N trades, so a cache that
+//is behind real time is only missing its newest tail) and merges that gap
+//into what was already cached instead of refetching and overwriting the lot.
+func (c *CachedAPIClient) GetTradeHistory(symbol string, number int) (*[]Trade, error) {
+	cached, err := c.Store.LoadTrades(symbol, time.Time{}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("apiclient: LoadTrades: %w", err)
+	}
+	if len(cached) >= number {
+		trimmed := cached[len(cached)-number:]
+		return &trimmed, nil
+	}
+
+	missing := number - len(cached)
+	trades, err := c.APIClient.GetTradeHistory(symbol, missing)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Store.SaveTrades(symbol, *trades); err != nil {
+		return nil, fmt.Errorf("apiclient: SaveTrades: %w", err)
+	}
+
+	merged := mergeTrades(cached, *trades)
+	if len(merged) > number {
+		merged = merged[len(merged)-number:]
+	}
+	return &merged, nil
+}
+
+//mergeTrades combines two ascending, Trade.Time-deduplicated runs into one
+//ascending run, keeping fresh's value on a timestamp collision.
+func mergeTrades(cached []Trade, fresh []Trade) []Trade {
+	byTime := make(map[int64]Trade, len(cached)+len(fresh))
+	for _, trade := range cached {
+		byTime[trade.Time] = trade
+	}
+	for _, trade := range fresh {
+		byTime[trade.Time] = trade
+	}
+
+	merged := make([]Trade, 0, len(byTime))
+	for _, trade := range byTime {
+		merged = append(merged, trade)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Time < merged[j].Time })
+	return merged
+}
+
+//defaultStreamWindowCandles bounds how many candles StreamKLine loads from
+//Store per LoadCandles call, so a wide [from, to] range is read in bounded
+//chunks rather than one giant query.
+const defaultStreamWindowCandles = 1000
+
+//StreamKLine walks [from, to] in fixed-size windows of candlePeriod-minute
+//candles and emits every stored candle on the returned channel in time
+//order. APIClient.GetKLine can only ever return the most recent N candles
+//relative to wall-clock time, so it has no way to serve an arbitrary
+//historical window; StreamKLine therefore reads purely from Store, which
+//CachedAPIClient's GetKLine keeps filled in as candles are requested over
+//time. A range (or part of one) that Store hasn't collected yet is simply
+//skipped rather than fetched live. The channel is closed once the range is
+//exhausted or a Store read fails.
+func (c *CachedAPIClient) StreamKLine(symbol string, candlePeriod int, from time.Time, to time.Time) (<-chan PriceCandle, error) {
+	windowDuration := time.Duration(defaultStreamWindowCandles*candlePeriod) * time.Minute
+
+	out := make(chan PriceCandle)
+	go func() {
+		defer close(out)
+
+		for cursor := from; cursor.Before(to); {
+			windowEnd := cursor.Add(windowDuration)
+			if windowEnd.After(to) {
+				windowEnd = to
+			}
+
+			candles, err := c.Store.LoadCandles(symbol, candlePeriod, cursor, windowEnd)
+			if err != nil {
+				return
+			}
+			for _, candle := range candles {
+				out <- candle
+			}
+
+			// LoadCandles is documented as inclusive on both ends and
+			// PriceCandle.Time is UNIX-seconds resolution, so the next
+			// window starts a full second past windowEnd to avoid
+			// re-reading (and re-emitting) the boundary candle.
+			cursor = windowEnd.Add(time.Second)
+		}
+	}()
+
+	return out, nil
+}
+
+//Resampler upsamples a stream of 1-minute candles into arbitrary periods
+//client-side, for exchanges whose native KLine endpoint doesn't support a
+//given interval.
+type Resampler struct {
+	periodMinutes int
+	current       *PriceCandle
+}
+
+//NewResampler builds a Resampler that emits one output candle per
+//periodMinutes of 1-minute input candles.
+func NewResampler(periodMinutes int) *Resampler {
+	return &Resampler{periodMinutes: periodMinutes}
+}
+
+//Add feeds a single 1-minute candle into the resampler. It returns the
+//completed output candle and true once periodMinutes worth of input has
+//been accumulated, or zero value and false while the period is still open.
+func (r *Resampler) Add(candle PriceCandle) (PriceCandle, bool) {
+	if r.current == nil {
+		bucket := candle
+		bucket.Time -= bucket.Time % int64(r.periodMinutes*60)
+		r.current = &bucket
+	}
+
+	if candle.High > r.current.High {
+		r.current.High = candle.High
+	}
+	if candle.Low < r.current.Low {
+		r.current.Low = candle.Low
+	}
+	r.current.Close = candle.Close
+
+	periodEnd := r.current.Time + int64(r.periodMinutes*60)
+	if candle.Time < periodEnd-60 {
+		return PriceCandle{}, false
+	}
+
+	completed := *r.current
+	r.current = nil
+	return completed, true
+}
@@ -0,0 +1,150 @@
+package apiclient
+
+import (
+	"testing"
+	"time"
+)
+
+//memHistoryStore is a minimal in-memory HistoryStore for tests.
+type memHistoryStore struct {
+	candles []PriceCandle
+	trades  []Trade
+
+	loadCandlesCalls [][2]int64 // [from.Unix(), to.Unix()] per LoadCandles call
+}
+
+func (s *memHistoryStore) LoadCandles(symbol string, period int, from time.Time, to time.Time) ([]PriceCandle, error) {
+	s.loadCandlesCalls = append(s.loadCandlesCalls, [2]int64{from.Unix(), to.Unix()})
+
+	var inRange []PriceCandle
+	for _, candle := range s.candles {
+		if candle.Time >= from.Unix() && candle.Time <= to.Unix() {
+			inRange = append(inRange, candle)
+		}
+	}
+	return inRange, nil
+}
+
+func (s *memHistoryStore) SaveCandles(symbol string, period int, candles []PriceCandle) error {
+	s.candles = mergeCandles(s.candles, candles)
+	return nil
+}
+
+func (s *memHistoryStore) LoadTrades(symbol string, from time.Time, to time.Time) ([]Trade, error) {
+	return append([]Trade{}, s.trades...), nil
+}
+
+func (s *memHistoryStore) SaveTrades(symbol string, trades []Trade) error {
+	s.trades = mergeTrades(s.trades, trades)
+	return nil
+}
+
+func (s *memHistoryStore) Close() error { return nil }
+
+//fakeKLineClient returns the last `number` candles ending now and records
+//how large each request was, so the test can assert that CachedAPIClient
+//only asks for the missing candles, not the full window.
+type fakeKLineClient struct {
+	APIClient
+	requests []int
+}
+
+func (f *fakeKLineClient) GetKLine(symbol string, candlePeriod int, number int) (*KLine, error) {
+	f.requests = append(f.requests, number)
+	now := time.Now()
+	candles := make([]PriceCandle, number)
+	for i := range candles {
+		// GetKLine's real contract is "last N candles ending now"
+		candles[i] = PriceCandle{Time: now.Add(-time.Duration(number-1-i) * time.Minute).Unix(), Close: float64(i)}
+	}
+	return &KLine{PriceCandles: candles}, nil
+}
+
+func TestCachedAPIClientGetKLineOnlyFetchesTheGap(t *testing.T) {
+	now := time.Now()
+	store := &memHistoryStore{candles: []PriceCandle{
+		{Time: now.Add(-5 * time.Minute).Unix(), Close: 1},
+		{Time: now.Add(-4 * time.Minute).Unix(), Close: 2},
+	}}
+	fake := &fakeKLineClient{}
+	cached := NewCachedAPIClient(fake, store)
+
+	kline, err := cached.GetKLine("USDT_BTC", 1, 5)
+	if err != nil {
+		t.Fatalf("GetKLine: %v", err)
+	}
+
+	if len(fake.requests) != 1 || fake.requests[0] != 3 {
+		t.Fatalf("expected exactly one underlying request for the 3 missing candles, got %v", fake.requests)
+	}
+	if len(kline.PriceCandles) != 5 {
+		t.Fatalf("expected 5 candles back, got %d", len(kline.PriceCandles))
+	}
+
+	// second call should be served entirely from the store now
+	if _, err := cached.GetKLine("USDT_BTC", 1, 5); err != nil {
+		t.Fatalf("GetKLine (cached): %v", err)
+	}
+	if len(fake.requests) != 1 {
+		t.Fatalf("expected no further underlying requests once the gap is filled, got %v", fake.requests)
+	}
+}
+
+func TestStreamKLineReadsDistinctWindows(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+
+	var candles []PriceCandle
+	for i := int64(0); i < 3000; i++ {
+		candles = append(candles, PriceCandle{Time: base.Add(time.Duration(i) * time.Minute).Unix(), Close: float64(i)})
+	}
+	store := &memHistoryStore{candles: candles}
+	cached := NewCachedAPIClient(&fakeKLineClient{}, store)
+
+	from := base
+	to := base.Add(2999 * time.Minute)
+
+	out, err := cached.StreamKLine("USDT_BTC", 1, from, to)
+	if err != nil {
+		t.Fatalf("StreamKLine: %v", err)
+	}
+
+	var seen []int64
+	for candle := range out {
+		seen = append(seen, candle.Time)
+	}
+
+	if len(seen) != len(candles) {
+		t.Fatalf("expected all %d stored candles, got %d", len(candles), len(seen))
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i] <= seen[i-1] {
+			t.Fatalf("candles out of order or duplicated at index %d: %v then %v", i, seen[i-1], seen[i])
+		}
+	}
+
+	if len(store.loadCandlesCalls) < 3 {
+		t.Fatalf("expected StreamKLine to walk the range in multiple distinct windows, got %d LoadCandles calls", len(store.loadCandlesCalls))
+	}
+	if store.loadCandlesCalls[0] == store.loadCandlesCalls[1] {
+		t.Fatalf("expected distinct windows per call, got the same range twice: %v", store.loadCandlesCalls[0])
+	}
+}
+
+func TestResamplerEmitsOnPeriodBoundary(t *testing.T) {
+	r := NewResampler(5)
+
+	for i := int64(0); i < 4; i++ {
+		minute := PriceCandle{Time: i * 60, Open: 1, High: 2, Low: 1, Close: 1}
+		if _, done := r.Add(minute); done {
+			t.Fatalf("resampler completed early at minute %d", i)
+		}
+	}
+
+	completed, done := r.Add(PriceCandle{Time: 4 * 60, Open: 1, High: 3, Low: 0.5, Close: 9})
+	if !done {
+		t.Fatalf("expected the 5th one-minute candle to complete the period")
+	}
+	if completed.High != 3 || completed.Low != 0.5 || completed.Close != 9 {
+		t.Fatalf("unexpected completed candle: %+v", completed)
+	}
+}
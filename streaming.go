@@ -0,0 +1,237 @@
+package apiclient
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//CancelFunc stops a subscription started by one of the Subscribe* methods.
+//Calling it more than once is a no-op.
+type CancelFunc func()
+
+//StreamingAPIClient interface for exchanges that expose push-based market
+//and account data alongside the request/response methods of APIClient.
+//
+//Implementations are expected to keep the returned channels alive across
+//reconnects: a dropped connection is retried internally (see ReconnectPolicy)
+//and, once restored, every previously active subscription is resent to the
+//exchange without the caller having to notice or resubscribe by hand.
+type StreamingAPIClient interface {
+	/* Subscribes to last-price updates on symbol.
+	 *
+	 * arguments:
+	 *   symbol  pair text-id in apiclient format.
+	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
+	 *
+	 * returns:
+	 *   Returns a channel that receives one apiclient.MarketData per update, a
+	 *   apiclient.CancelFunc to stop the subscription and error.
+	 */
+	SubscribeTicker(symbol string) (<-chan MarketData, CancelFunc, error)
+
+	/* Subscribes to order book updates on symbol and keeps a local snapshot
+	 * in sync with the exchange.
+	 *
+	 * arguments:
+	 *   symbol  pair text-id in apiclient format.
+	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
+	 *   depth   not required argument (default value 50)
+	 *      (how many orders you whant to see on Buy/Sell side)
+	 *
+	 * returns:
+	 *   Returns a channel that receives the reconciled apiclient.OrderBook on
+	 *   every snapshot or delta, a apiclient.CancelFunc to stop the
+	 *   subscription and error.
+	 */
+	SubscribeOrderBook(symbol string, depth ...int) (<-chan *OrderBook, CancelFunc, error)
+
+	/* Subscribes to the public trade feed on symbol.
+	 *
+	 * arguments:
+	 *   symbol  pair text-id in apiclient format.
+	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
+	 *
+	 * returns:
+	 *   Returns a channel that receives one apiclient.Trade per print, a
+	 *   apiclient.CancelFunc to stop the subscription and error.
+	 */
+	SubscribeTrades(symbol string) (<-chan Trade, CancelFunc, error)
+
+	/* Subscribes to price candle updates on symbol.
+	 *
+	 * arguments:
+	 *   symbol  pair text-id in apiclient format.
+	 * 		(example "USDT_BTC" main currency on the left, on the right is the currency that we buy when calling Buy)
+	 *   candlePeriod  candle period in minutes (1m = 1, 1h = 60, ...)
+	 *
+	 * returns:
+	 *   Returns a channel that receives the current, still-forming candle on
+	 *   every update, a apiclient.CancelFunc to stop the subscription and
+	 *   error.
+	 */
+	SubscribeKLine(symbol string, candlePeriod int) (<-chan PriceCandle, CancelFunc, error)
+
+	/* Subscribes to this account's order fills and balance changes.
+	 * Requires the client to have been initialized with Init/NewClient.
+	 *
+	 * returns:
+	 *   Returns a channel that receives a apiclient.MakedOrder on every
+	 *   order update, a channel that receives a apiclient.Balance on every
+	 *   balance change, a apiclient.CancelFunc to stop the subscription and
+	 *   error.
+	 */
+	SubscribeUserData() (<-chan MakedOrder, <-chan Balance, CancelFunc, error)
+}
+
+//ReconnectPolicy configures how a StreamingAPIClient implementation behaves
+//when the underlying websocket connection drops.
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first reconnect attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between reconnect attempts.
+	MaxBackoff time.Duration
+	// HeartbeatInterval is how often a ping/heartbeat frame is sent to keep
+	// the connection alive and detect a dead socket early.
+	HeartbeatInterval time.Duration
+	// HeartbeatTimeout is how long to wait for a pong/heartbeat reply before
+	// the connection is considered dead and a reconnect is triggered.
+	HeartbeatTimeout time.Duration
+}
+
+//DefaultReconnectPolicy is used by StreamDriver implementations that are
+//constructed without an explicit ReconnectPolicy.
+var DefaultReconnectPolicy = ReconnectPolicy{
+	InitialBackoff:    time.Second,
+	MaxBackoff:        time.Minute,
+	HeartbeatInterval: 15 * time.Second,
+	HeartbeatTimeout:  5 * time.Second,
+}
+
+//StreamDriver is the per-exchange plug behind StreamingAPIClient. Each
+//exchange implements one driver (dial the websocket, frame/parse exchange
+//specific messages, resubscribe on reconnect) and is wrapped by a shared
+//StreamingAPIClient implementation that owns reconnection, heartbeats and
+//order book reconciliation so that logic is written once instead of per
+//exchange.
+type StreamDriver interface {
+	// Dial opens the websocket connection. It is called once on start and
+	// again after every reconnect.
+	Dial() error
+
+	// Resubscribe resends every channel this driver currently considers
+	// active. Called after a successful Dial following a reconnect.
+	Resubscribe() error
+
+	// Close closes the underlying connection.
+	Close() error
+}
+
+//OrderBookSync reconciles a REST order book snapshot (from GetOrderBook)
+//with a stream of incremental deltas, the way Binance/Bitfinex/Kraken
+//websocket feeds expect consumers to do it.
+type OrderBookSync struct {
+	Symbol string
+
+	// LastSequence is the sequence number of the last delta applied. A gap
+	// between LastSequence and the next delta's sequence number means the
+	// local snapshot is stale and must be resynced via GetOrderBook.
+	LastSequence int64
+
+	// ChecksumDepth is how many levels per side Checksum folds in. Defaults
+	// to defaultChecksumDepth when left zero.
+	ChecksumDepth int
+
+	Book *OrderBook
+}
+
+const defaultChecksumDepth = 10
+
+//ApplyDelta applies a single incremental order book update on top of the
+//current snapshot, keeping OrderBook's documented sort order (bids
+//descending, asks ascending) intact. Once LastSequence is non-zero,
+//sequence must be exactly LastSequence+1; any other value means a delta was
+//missed and the caller should resync from GetOrderBook. While LastSequence
+//is zero (no delta applied yet), any sequence is accepted. When expectedChecksum is given, it is
+//compared against Checksum(ChecksumDepth) after the delta is applied; a
+//mismatch means the local book has drifted from the exchange's and should
+//also be resynced from GetOrderBook.
+func (s *OrderBookSync) ApplyDelta(sequence int64, side Side, level Order, expectedChecksum ...uint32) error {
+	if s.LastSequence != 0 && sequence != s.LastSequence+1 {
+		return ErrOrderBookGap
+	}
+
+	levels := &s.Book.Bids
+	descending := true
+	if side == Sell {
+		levels = &s.Book.Asks
+		descending = false
+	}
+
+	replaced := false
+	for i := range *levels {
+		if (*levels)[i].Price == level.Price {
+			if level.Quantity == 0 {
+				*levels = append((*levels)[:i], (*levels)[i+1:]...)
+			} else {
+				(*levels)[i].Quantity = level.Quantity
+			}
+			replaced = true
+			break
+		}
+	}
+	if !replaced && level.Quantity != 0 {
+		insertSorted(levels, level, descending)
+	}
+
+	s.LastSequence = sequence
+
+	if len(expectedChecksum) > 0 {
+		depth := s.ChecksumDepth
+		if depth == 0 {
+			depth = defaultChecksumDepth
+		}
+		if s.Checksum(depth) != expectedChecksum[0] {
+			return ErrChecksumMismatch
+		}
+	}
+
+	return nil
+}
+
+//insertSorted inserts level into *levels, keeping it sorted descending (for
+//bids) or ascending (for asks) by Price.
+func insertSorted(levels *[]Order, level Order, descending bool) {
+	idx := len(*levels)
+	for i, existing := range *levels {
+		if (descending && level.Price > existing.Price) || (!descending && level.Price < existing.Price) {
+			idx = i
+			break
+		}
+	}
+
+	*levels = append(*levels, Order{})
+	copy((*levels)[idx+1:], (*levels)[idx:])
+	(*levels)[idx] = level
+}
+
+//Checksum folds the top depth levels per side into a CRC32 the way
+//exchanges that expose a websocket checksum field (example: OKX, Bitfinex)
+//expect consumers to reproduce: "price:quantity:" for each bid then each
+//ask, best price first.
+func (s *OrderBookSync) Checksum(depth int) uint32 {
+	var b strings.Builder
+	for i := 0; i < depth && i < len(s.Book.Bids); i++ {
+		fmt.Fprintf(&b, "%s:%s:", trimFloat(s.Book.Bids[i].Price), trimFloat(s.Book.Bids[i].Quantity))
+	}
+	for i := 0; i < depth && i < len(s.Book.Asks); i++ {
+		fmt.Fprintf(&b, "%s:%s:", trimFloat(s.Book.Asks[i].Price), trimFloat(s.Book.Asks[i].Quantity))
+	}
+	return crc32.ChecksumIEEE([]byte(b.String()))
+}
+
+func trimFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,81 @@
+package apiclient
+
+import "testing"
+
+func TestOrderBookSyncApplyDeltaKeepsSortOrder(t *testing.T) {
+	sync := &OrderBookSync{
+		Book: &OrderBook{
+			Bids: []Order{{Price: 100, Quantity: 1}, {Price: 90, Quantity: 1}},
+			Asks: []Order{{Price: 110, Quantity: 1}, {Price: 120, Quantity: 1}},
+		},
+	}
+
+	// a new best bid should land at the front, not get appended to the tail
+	if err := sync.ApplyDelta(1, Buy, Order{Price: 105, Quantity: 2}); err != nil {
+		t.Fatalf("ApplyDelta: %v", err)
+	}
+	wantBids := []float64{105, 100, 90}
+	for i, price := range wantBids {
+		if sync.Book.Bids[i].Price != price {
+			t.Fatalf("bids not descending after insert: %+v", sync.Book.Bids)
+		}
+	}
+
+	// a new best ask should land at the front of asks too
+	if err := sync.ApplyDelta(2, Sell, Order{Price: 105, Quantity: 2}); err != nil {
+		t.Fatalf("ApplyDelta: %v", err)
+	}
+	wantAsks := []float64{105, 110, 120}
+	for i, price := range wantAsks {
+		if sync.Book.Asks[i].Price != price {
+			t.Fatalf("asks not ascending after insert: %+v", sync.Book.Asks)
+		}
+	}
+
+	// a level removed by a zero-quantity delta should not leave a hole in the order
+	if err := sync.ApplyDelta(3, Buy, Order{Price: 100, Quantity: 0}); err != nil {
+		t.Fatalf("ApplyDelta: %v", err)
+	}
+	wantBids = []float64{105, 90}
+	for i, price := range wantBids {
+		if sync.Book.Bids[i].Price != price {
+			t.Fatalf("bids not sorted after removal: %+v", sync.Book.Bids)
+		}
+	}
+}
+
+func TestOrderBookSyncApplyDeltaSequenceGap(t *testing.T) {
+	sync := &OrderBookSync{Book: &OrderBook{}, LastSequence: 5}
+
+	if err := sync.ApplyDelta(7, Buy, Order{Price: 100, Quantity: 1}); err != ErrOrderBookGap {
+		t.Fatalf("expected ErrOrderBookGap, got %v", err)
+	}
+}
+
+func TestOrderBookSyncApplyDeltaChecksumMismatch(t *testing.T) {
+	newBook := func() *OrderBookSync {
+		return &OrderBookSync{
+			Book: &OrderBook{
+				Bids: []Order{{Price: 100, Quantity: 1}},
+				Asks: []Order{{Price: 110, Quantity: 1}},
+			},
+		}
+	}
+
+	sync := newBook()
+	if err := sync.ApplyDelta(1, Buy, Order{Price: 99, Quantity: 1}, 0xdeadbeef); err != ErrChecksumMismatch {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	// compute what the checksum should be after that same delta on a fresh book
+	reference := newBook()
+	if err := reference.ApplyDelta(1, Buy, Order{Price: 99, Quantity: 1}); err != nil {
+		t.Fatalf("reference ApplyDelta: %v", err)
+	}
+	want := reference.Checksum(defaultChecksumDepth)
+
+	sync = newBook()
+	if err := sync.ApplyDelta(1, Buy, Order{Price: 99, Quantity: 1}, want); err != nil {
+		t.Fatalf("ApplyDelta with matching checksum: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package apiclient
+
+import (
+	"fmt"
+	"sync"
+)
+
+//Market help struct for APIClient, describing a single tradable pair in
+//exchange-agnostic terms so strategy code can be written once and run
+//against any exchange behind this interface.
+type Market struct {
+	Symbol string // native symbol, in apiclient format (example "USDT_BTC")
+	Base   string // base currency id, uppercase (example "BTC")
+	Quote  string // quote currency id, uppercase (example "USDT")
+
+	MinAmount float64 // smallest order amount the exchange accepts
+	MaxAmount float64 // largest order amount the exchange accepts, 0 if unbounded
+
+	StepSize float64 // order amount must be a multiple of StepSize
+	TickSize float64 // order price must be a multiple of TickSize
+
+	MinNotional float64 // minimum amount*price (or quote amount) accepted
+
+	MakerFee float64 // maker fee, as a fraction (0.001 == 0.1%)
+	TakerFee float64 // taker fee, as a fraction (0.001 == 0.1%)
+
+	Status string // exchange-reported status (example "TRADING", "HALT")
+}
+
+//SymbolRegistry is an in-memory index of an exchange's tradable pairs,
+//built from GetMarkets (or GetTradingPairs as a fallback) and used to
+//resolve/parse symbols without hitting the exchange on every call.
+//Implementations of APIClient typically hold one SymbolRegistry and
+//refresh it lazily on first use or on a ResolveSymbol/ParseSymbol miss.
+type SymbolRegistry struct {
+	mu       sync.RWMutex
+	byNative map[string]Market
+	byPair   map[string]string // "BASE/QUOTE" -> native symbol
+}
+
+//NewSymbolRegistry builds an empty SymbolRegistry. Call Load to populate
+//it from a []Market, typically the dereferenced result of GetMarkets.
+func NewSymbolRegistry() *SymbolRegistry {
+	return &SymbolRegistry{
+		byNative: make(map[string]Market),
+		byPair:   make(map[string]string),
+	}
+}
+
+//Load replaces the registry's contents with markets. Safe to call again
+//to refresh after GetMarkets is re-polled.
+func (r *SymbolRegistry) Load(markets []Market) {
+	byNative := make(map[string]Market, len(markets))
+	byPair := make(map[string]string, len(markets))
+	for _, m := range markets {
+		byNative[m.Symbol] = m
+		byPair[pairKey(m.Base, m.Quote)] = m.Symbol
+	}
+
+	r.mu.Lock()
+	r.byNative = byNative
+	r.byPair = byPair
+	r.mu.Unlock()
+}
+
+//Resolve returns the native symbol for a base/quote pair.
+func (r *SymbolRegistry) Resolve(base string, quote string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	symbol, ok := r.byPair[pairKey(base, quote)]
+	if !ok {
+		return "", fmt.Errorf("apiclient: no market for %s/%s", base, quote)
+	}
+	return symbol, nil
+}
+
+//Parse returns the base/quote pair for a native symbol.
+func (r *SymbolRegistry) Parse(symbol string) (base string, quote string, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.byNative[symbol]
+	if !ok {
+		return "", "", fmt.Errorf("apiclient: unknown symbol %s", symbol)
+	}
+	return m.Base, m.Quote, nil
+}
+
+//Market returns the full Market for a native symbol.
+func (r *SymbolRegistry) Market(symbol string) (*Market, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.byNative[symbol]
+	if !ok {
+		return nil, fmt.Errorf("apiclient: unknown symbol %s", symbol)
+	}
+	return &m, nil
+}
+
+func pairKey(base string, quote string) string {
+	return base + "/" + quote
+}